@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/subscriptions"
+)
+
+// parseDomainPermissionType parses a gtsmodel.DomainPermissionType
+// out of the given string, or returns a bad request error if the
+// string doesn't correspond to a known permission type.
+func parseDomainPermissionType(in string) (gtsmodel.DomainPermissionType, gtserror.WithCode) {
+	switch in {
+	case "allow":
+		return gtsmodel.DomainPermissionAllow, nil
+	case "block":
+		return gtsmodel.DomainPermissionBlock, nil
+	default:
+		err := fmt.Errorf("permission_type %s not recognized, valid options are allow, block", in)
+		return 0, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
+// parseDomainPermSubContentType parses a gtsmodel.DomainPermSubContentType
+// out of the given string, or returns a bad request error if the string
+// doesn't correspond to a known content type.
+func parseDomainPermSubContentType(in string) (gtsmodel.DomainPermSubContentType, gtserror.WithCode) {
+	switch in {
+	case "text/csv":
+		return gtsmodel.DomainPermSubContentTypeCSV, nil
+	case "text/plain":
+		return gtsmodel.DomainPermSubContentTypePlain, nil
+	case "application/json":
+		return gtsmodel.DomainPermSubContentTypeJSON, nil
+	case "application/vnd.mastodon.domainblocks+json":
+		return gtsmodel.DomainPermSubContentTypeMastodonJSON, nil
+	default:
+		const errText = "content_type not recognized, valid options are " +
+			"text/csv, text/plain, application/json, application/vnd.mastodon.domainblocks+json"
+		err := fmt.Errorf(errText)
+		return 0, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
+// parseDomainPermSubFetchAuthType parses a gtsmodel.DomainPermSubFetchAuthType
+// out of the given string. An empty string defaults to "none".
+func parseDomainPermSubFetchAuthType(in string) (gtsmodel.DomainPermSubFetchAuthType, gtserror.WithCode) {
+	switch in {
+	case "", "none":
+		return gtsmodel.DomainPermSubFetchAuthTypeNone, nil
+	case "basic":
+		return gtsmodel.DomainPermSubFetchAuthTypeBasic, nil
+	case "bearer":
+		return gtsmodel.DomainPermSubFetchAuthTypeBearer, nil
+	case "http_signature":
+		return gtsmodel.DomainPermSubFetchAuthTypeHTTPSignature, nil
+	default:
+		err := fmt.Errorf("fetch_auth_type %s not recognized, valid options are none, basic, bearer, http_signature", in)
+		return 0, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
+// parseDomainPermSubSeverityMapping parses a severity mapping, provided
+// as a JSON-encoded object of upstream severity -> GtS permission type,
+// out of the given string. An empty string is not an error; it just
+// results in a nil mapping (ie., callers should fall back to defaults).
+//
+// Severities the admin doesn't mention are merged in from
+// subscriptions.DefaultSeverityMapping() field by field, rather
+// than left at their Go zero value, so that eg. a mapping of
+// just {"silence":"block"} still blocks "suspend" entries as
+// documented instead of silently ignoring them.
+func parseDomainPermSubSeverityMapping(in string) (*gtsmodel.DomainPermSubSeverityMapping, gtserror.WithCode) {
+	if in == "" {
+		return nil, nil
+	}
+
+	var raw struct {
+		Suspend *string `json:"suspend"`
+		Silence *string `json:"silence"`
+		Noop    *string `json:"noop"`
+	}
+	if err := json.Unmarshal([]byte(in), &raw); err != nil {
+		err := fmt.Errorf("could not parse severity_mapping: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	defaults := subscriptions.DefaultSeverityMapping()
+	mapping := &defaults
+
+	if raw.Suspend != nil {
+		permType, errWithCode := parseDomainPermSubSeverityValue(*raw.Suspend)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		mapping.Suspend = permType
+	}
+
+	if raw.Silence != nil {
+		permType, errWithCode := parseDomainPermSubSeverityValue(*raw.Silence)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		mapping.Silence = permType
+	}
+
+	if raw.Noop != nil {
+		permType, errWithCode := parseDomainPermSubSeverityValue(*raw.Noop)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		mapping.Noop = permType
+	}
+
+	return mapping, nil
+}
+
+// parseDomainPermSubSeverityValue parses one value of a severity_mapping
+// object, which (unlike permission_type) may also be "none" to indicate
+// that entries with this severity should be ignored entirely.
+func parseDomainPermSubSeverityValue(in string) (gtsmodel.DomainPermissionType, gtserror.WithCode) {
+	if in == "none" {
+		return gtsmodel.DomainPermissionNone, nil
+	}
+	return parseDomainPermissionType(in)
+}
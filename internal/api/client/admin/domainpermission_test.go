@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestParseDomainPermSubContentType(t *testing.T) {
+	ct, errWithCode := parseDomainPermSubContentType("application/vnd.mastodon.domainblocks+json")
+	require.Nil(t, errWithCode)
+	assert.Equal(t, gtsmodel.DomainPermSubContentTypeMastodonJSON, ct)
+
+	_, errWithCode = parseDomainPermSubContentType("application/xml")
+	require.NotNil(t, errWithCode)
+}
+
+func TestParseDomainPermSubFetchAuthType(t *testing.T) {
+	at, errWithCode := parseDomainPermSubFetchAuthType("")
+	require.Nil(t, errWithCode)
+	assert.Equal(t, gtsmodel.DomainPermSubFetchAuthTypeNone, at)
+
+	at, errWithCode = parseDomainPermSubFetchAuthType("bearer")
+	require.Nil(t, errWithCode)
+	assert.Equal(t, gtsmodel.DomainPermSubFetchAuthTypeBearer, at)
+
+	_, errWithCode = parseDomainPermSubFetchAuthType("oauth2")
+	require.NotNil(t, errWithCode)
+}
+
+func TestParseDomainPermSubSeverityMapping(t *testing.T) {
+	mapping, errWithCode := parseDomainPermSubSeverityMapping("")
+	require.Nil(t, errWithCode)
+	assert.Nil(t, mapping)
+
+	mapping, errWithCode = parseDomainPermSubSeverityMapping(`{"suspend":"block","silence":"none","noop":"allow"}`)
+	require.Nil(t, errWithCode)
+	require.NotNil(t, mapping)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, mapping.Suspend)
+	assert.Equal(t, gtsmodel.DomainPermissionNone, mapping.Silence)
+	assert.Equal(t, gtsmodel.DomainPermissionAllow, mapping.Noop)
+
+	// A partial mapping should have its unspecified
+	// severities merged in from the defaults, not zeroed out.
+	mapping, errWithCode = parseDomainPermSubSeverityMapping(`{"silence":"block"}`)
+	require.Nil(t, errWithCode)
+	require.NotNil(t, mapping)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, mapping.Suspend)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, mapping.Silence)
+	assert.Equal(t, gtsmodel.DomainPermissionNone, mapping.Noop)
+
+	_, errWithCode = parseDomainPermSubSeverityMapping(`{"suspend":"not_a_real_severity"}`)
+	require.NotNil(t, errWithCode)
+
+	_, errWithCode = parseDomainPermSubSeverityMapping(`not json`)
+	require.NotNil(t, errWithCode)
+}
@@ -27,6 +27,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -106,21 +107,52 @@ import (
 //		in: formData
 //		description: >-
 //			MIME content type to use when parsing the permissions list.
-//			One of "text/plain", "text/csv", and "application/json".
+//			One of "text/plain", "text/csv", "application/json", or
+//			"application/vnd.mastodon.domainblocks+json" (Mastodon-compatible
+//			domainblocks.json, as used by Oliphant-style shared blocklists).
+//		type: string
+//	-
+//		name: severity_mapping
+//		in: formData
+//		description: >-
+//			Only used if content_type is "application/vnd.mastodon.domainblocks+json".
+//			JSON-encoded object mapping upstream Mastodon severities ("suspend",
+//			"silence", "noop") onto GtS permission types ("block", "allow", "none").
+//			Severities not present in the mapping fall back to defaults of
+//			suspend -> block, silence -> none, noop -> none.
+//		type: string
+//	-
+//		name: fetch_auth_type
+//		in: formData
+//		description: >-
+//			Type of auth to use when fetching given uri.
+//			One of "none", "basic", "bearer", "http_signature".
+//			Defaults to "basic" if fetch_username or fetch_password
+//			is set, and to "none" otherwise.
 //		type: string
 //	-
 //		name: fetch_username
 //		in: formData
 //		description: >-
-//			Optional basic auth username to provide when fetching given uri.
-//			If set, will be transmitted along with `fetch_password` when doing the fetch.
+//			Only used if fetch_auth_type is "basic". Basic auth username
+//			to provide when fetching given uri. If set, will be transmitted
+//			along with `fetch_password` when doing the fetch.
 //		type: string
 //	-
 //		name: fetch_password
 //		in: formData
 //		description: >-
-//			Optional basic auth password to provide when fetching given uri.
-//			If set, will be transmitted along with `fetch_username` when doing the fetch.
+//			Only used if fetch_auth_type is "basic". Basic auth password
+//			to provide when fetching given uri. If set, will be transmitted
+//			along with `fetch_username` when doing the fetch.
+//		type: string
+//	-
+//		name: fetch_token
+//		in: formData
+//		description: >-
+//			Only used if fetch_auth_type is "bearer". Bearer token to
+//			provide when fetching given uri, as an "Authorization: Bearer"
+//			header.
 //		type: string
 //
 //	security:
@@ -223,6 +255,39 @@ func (m *Module) DomainPermissionSubscriptionPOSTHandler(c *gin.Context) {
 	// Default `as_draft` to true.
 	asDraft := util.PtrOrValue(form.AsDraft, true)
 
+	// Default `adopt_orphans` to false.
+	adoptOrphans := util.PtrOrValue(form.AdoptOrphans, false)
+
+	// Severity mapping is optional, and only
+	// meaningful for Mastodon-style domainblocks.json.
+	severityMapping, errWithCode := parseDomainPermSubSeverityMapping(util.PtrOrZero(form.SeverityMapping))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Fetch auth type defaults to "basic" if fetch_username or
+	// fetch_password is set (for backwards compatibility with
+	// existing basic-auth-only subscriptions), else "none".
+	fetchAuthTypeStr := util.PtrOrZero(form.FetchAuthType)
+	if fetchAuthTypeStr == "" &&
+		(util.PtrOrZero(form.FetchUsername) != "" || util.PtrOrZero(form.FetchPassword) != "") {
+		fetchAuthTypeStr = "basic"
+	}
+	fetchAuthType, errWithCode := parseDomainPermSubFetchAuthType(fetchAuthTypeStr)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Bearer auth requires a token.
+	if fetchAuthType == gtsmodel.DomainPermSubFetchAuthTypeBearer && util.PtrOrZero(form.FetchToken) == "" {
+		const errText = "fetch_token must be set when fetch_auth_type is bearer"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	permSub, errWithCode := m.processor.Admin().DomainPermissionSubscriptionCreate(
 		c.Request.Context(),
 		authed.Account,
@@ -232,8 +297,12 @@ func (m *Module) DomainPermissionSubscriptionPOSTHandler(c *gin.Context) {
 		contentType,
 		permType,
 		asDraft,
+		adoptOrphans,
+		severityMapping,
+		fetchAuthType,
 		util.PtrOrZero(form.FetchUsername), // Optional.
 		util.PtrOrZero(form.FetchPassword), // Optional.
+		util.PtrOrZero(form.FetchToken),    // Optional.
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -0,0 +1,249 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainPermissionSubscriptionPreviewPOSTHandler swagger:operation POST /api/v1/admin/domain_permission_subscriptions/preview domainPermissionSubscriptionPreview
+//
+// Preview the effect of creating a domain permission subscription with the given parameters,
+// without actually creating it or any of the domain permissions it would give rise to.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: priority
+//		in: formData
+//		description: Priority of this subscription compared to others of the same permission type.
+//		type: number
+//		minimum: 0
+//		maximum: 255
+//		default: 0
+//	-
+//		name: permission_type
+//		required: true
+//		in: formData
+//		description: Type of permissions that would be created by parsing the targeted file/list.
+//		type: string
+//	-
+//		name: adopt_orphans
+//		in: formData
+//		description: If true, the preview will indicate which existing orphaned domain permissions would be adopted.
+//		type: boolean
+//		default: false
+//	-
+//		name: uri
+//		required: true
+//		in: formData
+//		description: URI to call in order to fetch the permissions list.
+//		type: string
+//	-
+//		name: content_type
+//		required: true
+//		in: formData
+//		description: >-
+//			MIME content type to use when parsing the permissions list.
+//			One of "text/plain", "text/csv", "application/json", or
+//			"application/vnd.mastodon.domainblocks+json".
+//		type: string
+//	-
+//		name: severity_mapping
+//		in: formData
+//		description: Only used if content_type is "application/vnd.mastodon.domainblocks+json". See domain_permission_subscriptions docs.
+//		type: string
+//	-
+//		name: fetch_auth_type
+//		in: formData
+//		description: Type of auth to use when fetching given uri. One of "none", "basic", "bearer", "http_signature".
+//		type: string
+//	-
+//		name: fetch_username
+//		in: formData
+//		description: Only used if fetch_auth_type is "basic".
+//		type: string
+//	-
+//		name: fetch_password
+//		in: formData
+//		description: Only used if fetch_auth_type is "basic".
+//		type: string
+//	-
+//		name: fetch_token
+//		in: formData
+//		description: Only used if fetch_auth_type is "bearer".
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: A preview of the domain permissions that this subscription would create.
+//			schema:
+//				"$ref": "#/definitions/domainPermissionSubscriptionPreview"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DomainPermissionSubscriptionPreviewPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Parse + validate form. This mirrors
+	// DomainPermissionSubscriptionPOSTHandler's validation,
+	// since a preview must be checked against the same rules
+	// that would apply if it were actually submitted.
+	form := new(apimodel.DomainPermissionSubscriptionRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	priority := util.PtrOrZero(form.Priority)
+	if priority < 0 || priority > 255 {
+		const errText = "priority must be a number in the range 0 to 255"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.URI == nil {
+		const errText = "uri must be set"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	uri, err := url.Parse(*form.URI)
+	if err != nil {
+		err := fmt.Errorf("invalid uri provided: %w", err)
+		errWithCode := gtserror.NewErrorBadRequest(err, err.Error())
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	uriStr := uri.String()
+
+	contentTypeStr := util.PtrOrZero(form.ContentType)
+	contentType, errWithCode := parseDomainPermSubContentType(contentTypeStr)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	permTypeStr := util.PtrOrZero(form.PermissionType)
+	permType, errWithCode := parseDomainPermissionType(permTypeStr)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	severityMapping, errWithCode := parseDomainPermSubSeverityMapping(util.PtrOrZero(form.SeverityMapping))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	fetchAuthTypeStr := util.PtrOrZero(form.FetchAuthType)
+	if fetchAuthTypeStr == "" &&
+		(util.PtrOrZero(form.FetchUsername) != "" || util.PtrOrZero(form.FetchPassword) != "") {
+		fetchAuthTypeStr = "basic"
+	}
+	fetchAuthType, errWithCode := parseDomainPermSubFetchAuthType(fetchAuthTypeStr)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Bearer auth requires a token.
+	if fetchAuthType == gtsmodel.DomainPermSubFetchAuthTypeBearer && util.PtrOrZero(form.FetchToken) == "" {
+		const errText = "fetch_token must be set when fetch_auth_type is bearer"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	adoptOrphans := util.PtrOrValue(form.AdoptOrphans, false)
+
+	preview, errWithCode := m.processor.Admin().DomainPermissionSubscriptionPreview(
+		c.Request.Context(),
+		uint8(priority), // #nosec G115 -- Validated above.
+		uriStr,
+		contentType,
+		permType,
+		adoptOrphans,
+		severityMapping,
+		fetchAuthType,
+		util.PtrOrZero(form.FetchUsername), // Optional.
+		util.PtrOrZero(form.FetchPassword), // Optional.
+		util.PtrOrZero(form.FetchToken),    // Optional.
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, preview)
+}
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainPermissionSubscriptionRequest is the form submitted
+// as part of a request to create or test a domain permission
+// subscription.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others
+	// of the same permission type. 0-255 (higher = higher priority).
+	Priority *uint8 `form:"priority" json:"priority,omitempty"`
+	// Optional title for this subscription.
+	Title *string `form:"title" json:"title,omitempty"`
+	// Type of permissions to create by parsing the targeted file/list.
+	// One of "allow" or "block".
+	PermissionType *string `form:"permission_type" json:"permission_type,omitempty"`
+	// If true, domain permissions arising from this subscription
+	// will be created as drafts requiring moderator approval.
+	AsDraft *bool `form:"as_draft" json:"as_draft,omitempty"`
+	// If true, this subscription will adopt existing orphaned
+	// domain permissions that are present in the subscribed list.
+	AdoptOrphans *bool `form:"adopt_orphans" json:"adopt_orphans,omitempty"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri,omitempty"`
+	// MIME content type to use when parsing the permissions list.
+	// One of "text/plain", "text/csv", "application/json", or
+	// "application/vnd.mastodon.domainblocks+json".
+	ContentType *string `form:"content_type" json:"content_type,omitempty"`
+	// SeverityMapping is a JSON-encoded object mapping upstream
+	// Mastodon-style severities ("suspend", "silence", "noop")
+	// onto GtS domain permission types ("block", "allow", "none").
+	// Only used when content_type is
+	// "application/vnd.mastodon.domainblocks+json". Unmapped
+	// severities fall back to sane defaults (suspend -> block,
+	// silence -> none, noop -> none).
+	SeverityMapping *string `form:"severity_mapping" json:"severity_mapping,omitempty"`
+	// Type of auth to use when fetching given uri. One of
+	// "none", "basic", "bearer", "http_signature". Defaults to
+	// "basic" if fetch_username or fetch_password is set, else "none".
+	FetchAuthType *string `form:"fetch_auth_type" json:"fetch_auth_type,omitempty"`
+	// Optional basic auth username to provide when fetching given uri.
+	// Only used if fetch_auth_type is "basic".
+	FetchUsername *string `form:"fetch_username" json:"fetch_username,omitempty"`
+	// Optional basic auth password to provide when fetching given uri.
+	// Only used if fetch_auth_type is "basic".
+	FetchPassword *string `form:"fetch_password" json:"fetch_password,omitempty"`
+	// Bearer token to provide when fetching given uri, as an
+	// "Authorization: Bearer <fetch_token>" header. Only used
+	// if fetch_auth_type is "bearer".
+	FetchToken *string `form:"fetch_token" json:"fetch_token,omitempty"`
+}
+
+// DomainPermissionSubscription models a subscription
+// to a remote list of domain permissions.
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// ID of this item.
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type.
+	Priority uint8 `json:"priority"`
+	// Title given to this subscription.
+	Title string `json:"title"`
+	// Permission type created by this subscription.
+	PermissionType string `json:"permission_type"`
+	// Domain permissions arising from this subscription are created as drafts.
+	AsDraft bool `json:"as_draft"`
+	// This subscription adopts orphaned domain permissions it encounters.
+	AdoptOrphans bool `json:"adopt_orphans"`
+	// Account that created this subscription.
+	CreatedBy string `json:"created_by"`
+	// URI of the subscribed list.
+	URI string `json:"uri"`
+	// Content type used to parse the subscribed list.
+	ContentType string `json:"content_type"`
+	// Severity mapping used when content_type is the Mastodon domainblocks format.
+	SeverityMapping *DomainPermSubSeverityMapping `json:"severity_mapping,omitempty"`
+	// Type of auth used to fetch the list.
+	FetchAuthType string `json:"fetch_auth_type"`
+	// Basic auth username used to fetch the list, if set.
+	FetchUsername string `json:"fetch_username,omitempty"`
+	// ETag returned by the most recent fetch of the subscribed list, if any.
+	ETag string `json:"etag,omitempty"`
+	// Last-Modified value returned by the most recent fetch of the subscribed list, if any.
+	LastModified string `json:"last_modified,omitempty"`
+	// Time the subscribed list was last fetched.
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time the subscribed list was last fetched and parsed without error.
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// Error encountered during the most recent fetch/parse, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// DomainPermissionSubscriptionPreview is the result of a dry-run
+// fetch + parse of a not-yet-created domain permission subscription,
+// showing what it would do if actually submitted.
+//
+// swagger:model domainPermissionSubscriptionPreview
+type DomainPermissionSubscriptionPreview struct {
+	// Domains for which a new domain permission would be created.
+	WouldCreate []DomainPermissionSubscriptionPreviewEntry `json:"would_create"`
+	// Domains already managed by this subscription whose existing
+	// domain permission would be refreshed to match the upstream list.
+	WouldUpdate []DomainPermissionSubscriptionPreviewEntry `json:"would_update"`
+	// Domains for which an existing orphaned domain permission
+	// would be adopted by this subscription (only populated if
+	// adopt_orphans was set on the preview request).
+	WouldAdopt []DomainPermissionSubscriptionPreviewEntry `json:"would_adopt"`
+	// Domains for which an existing domain permission, currently
+	// managed by a lower-priority subscription, would be taken
+	// over by this one.
+	WouldOverwrite []DomainPermissionSubscriptionPreviewEntry `json:"would_overwrite"`
+	// Domains for which this subscription's entry would be
+	// superseded by a higher-(or-equal-)priority subscription's
+	// entry, and so would have no effect.
+	Superseded []DomainPermissionSubscriptionPreviewEntry `json:"superseded"`
+	// Raw lines/entries from the list that couldn't be parsed.
+	// Unusable entries are skipped by the parsers without being
+	// recorded, so this is always empty (null).
+	Malformed []string `json:"malformed"`
+}
+
+// DomainPermissionSubscriptionPreviewEntry represents a single
+// domain permission that a previewed subscription would create,
+// adopt, or have superseded.
+//
+// swagger:model domainPermissionSubscriptionPreviewEntry
+type DomainPermissionSubscriptionPreviewEntry struct {
+	// Domain this entry pertains to.
+	Domain string `json:"domain"`
+	// Permission type ("block" or "allow") this entry would create.
+	PermissionType string `json:"permission_type"`
+}
+
+// DomainPermSubSeverityMapping is the API representation of
+// gtsmodel.DomainPermSubSeverityMapping.
+//
+// swagger:model domainPermSubSeverityMapping
+type DomainPermSubSeverityMapping struct {
+	// GtS permission type to create for upstream "suspend" entries.
+	Suspend string `json:"suspend,omitempty"`
+	// GtS permission type to create for upstream "silence" entries.
+	Silence string `json:"silence,omitempty"`
+	// GtS permission type to create for upstream "noop" entries.
+	Noop string `json:"noop,omitempty"`
+}
@@ -0,0 +1,243 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/subscriptions"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+// domainPermissionSubscriptionDB is the bun-backed
+// implementation of db.DomainPermissionSubscription.
+type domainPermissionSubscriptionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *domainPermissionSubscriptionDB) PutDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) error {
+	_, err := d.db.NewInsert().Model(permSub).Exec(ctx)
+	return err
+}
+
+func (d *domainPermissionSubscriptionDB) UpdateDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, columns ...string) error {
+	permSub.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// Always update updated_at.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.NewUpdate().
+		Model(permSub).
+		Column(columns...).
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+func (d *domainPermissionSubscriptionDB) DiffDomainPermissionsFromSubscription(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	entries []subscriptions.Entry,
+) (*gtsmodel.DomainPermissionSubscriptionDiff, error) {
+	diff := &gtsmodel.DomainPermissionSubscriptionDiff{}
+
+	for _, entry := range entries {
+		existing, err := d.getDomainPermissionByDomain(ctx, entry.Domain, entry.PermissionType)
+		if err != nil {
+			return nil, fmt.Errorf("error checking existing domain permission for %s: %w", entry.Domain, err)
+		}
+
+		switch {
+		case existing == nil:
+			// No permission for this domain yet.
+			diff.WouldCreate = append(diff.WouldCreate, newDomainPermission(permSub, entry))
+
+		case existing.SubscriptionID == "" && util.PtrOrZero(permSub.AdoptOrphans):
+			// Orphaned permission, and this
+			// subscription is willing to adopt it.
+			diff.WouldAdopt = append(diff.WouldAdopt, updatedDomainPermission(existing, permSub, entry))
+
+		case existing.SubscriptionID == "":
+			// Orphaned permission this subscription isn't
+			// configured to adopt; leave it alone.
+
+		case existing.SubscriptionID == permSub.ID:
+			// Already managed by this subscription; propagate
+			// any upstream edit (comment/obfuscate/permission
+			// type) to the existing permission.
+			diff.WouldUpdate = append(diff.WouldUpdate, updatedDomainPermission(existing, permSub, entry))
+
+		default:
+			// Managed by a different subscription. Higher
+			// priority subscriptions overwrite permissions
+			// generated by lower priority ones, so only treat
+			// this as superseded if the existing owner's
+			// priority is at least as high as permSub's.
+			ownerPriority, err := d.getSubscriptionPriority(ctx, existing.SubscriptionID)
+			if err != nil {
+				return nil, fmt.Errorf("error checking priority of subscription %s owning %s: %w", existing.SubscriptionID, entry.Domain, err)
+			}
+
+			if ownerPriority >= permSub.Priority {
+				diff.Superseded = append(diff.Superseded, updatedDomainPermission(existing, permSub, entry))
+			} else {
+				diff.WouldOverwrite = append(diff.WouldOverwrite, updatedDomainPermission(existing, permSub, entry))
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+func (d *domainPermissionSubscriptionDB) UpsertDomainPermissionsFromSubscription(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	entries []subscriptions.Entry,
+) error {
+	diff, err := d.DiffDomainPermissionsFromSubscription(ctx, permSub, entries)
+	if err != nil {
+		return fmt.Errorf("error diffing subscription against existing permissions: %w", err)
+	}
+
+	return d.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, perm := range diff.WouldCreate {
+			perm.ID = id.NewULID()
+			if _, err := tx.NewInsert().Model(perm).Exec(ctx); err != nil {
+				return fmt.Errorf("error creating domain permission for %s: %w", perm.Domain, err)
+			}
+		}
+
+		for _, perm := range diff.WouldUpdate {
+			if _, err := tx.NewUpdate().
+				Model(perm).
+				Column("public_comment", "private_comment", "obfuscate", "permission_type", "updated_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error updating domain permission for %s: %w", perm.Domain, err)
+			}
+		}
+
+		for _, perm := range diff.WouldAdopt {
+			if _, err := tx.NewUpdate().
+				Model(perm).
+				Column("subscription_id", "permission_type", "updated_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error adopting domain permission for %s: %w", perm.Domain, err)
+			}
+		}
+
+		for _, perm := range diff.WouldOverwrite {
+			if _, err := tx.NewUpdate().
+				Model(perm).
+				Column("subscription_id", "permission_type", "updated_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error taking over domain permission for %s: %w", perm.Domain, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// newDomainPermission builds a not-yet-inserted DomainPermission
+// for entry, to be created on behalf of permSub.
+func newDomainPermission(permSub *gtsmodel.DomainPermissionSubscription, entry subscriptions.Entry) *gtsmodel.DomainPermission {
+	obfuscate := entry.Obfuscate
+	return &gtsmodel.DomainPermission{
+		Domain:             entry.Domain,
+		PermissionType:     entry.PermissionType,
+		CreatedByAccountID: permSub.CreatedByAccountID,
+		PublicComment:      entry.PublicComment,
+		PrivateComment:     entry.PrivateComment,
+		Obfuscate:          &obfuscate,
+		SubscriptionID:     permSub.ID,
+	}
+}
+
+// updatedDomainPermission builds an update-ready DomainPermission
+// for entry, reusing existing's identity and CreatedAt rather than
+// zero-valuing them, so that adopting/overwriting/refreshing a
+// domain permission doesn't clobber its creation metadata or write
+// a zero-value UpdatedAt into the database.
+func updatedDomainPermission(existing *gtsmodel.DomainPermission, permSub *gtsmodel.DomainPermissionSubscription, entry subscriptions.Entry) *gtsmodel.DomainPermission {
+	obfuscate := entry.Obfuscate
+	return &gtsmodel.DomainPermission{
+		ID:                 existing.ID,
+		Domain:             entry.Domain,
+		PermissionType:     entry.PermissionType,
+		CreatedByAccountID: existing.CreatedByAccountID,
+		PublicComment:      entry.PublicComment,
+		PrivateComment:     entry.PrivateComment,
+		Obfuscate:          &obfuscate,
+		SubscriptionID:     permSub.ID,
+		CreatedAt:          existing.CreatedAt,
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// getDomainPermissionByDomain returns the existing domain
+// permission of permType for domain, or nil if none exists.
+func (d *domainPermissionSubscriptionDB) getDomainPermissionByDomain(ctx context.Context, domain string, permType gtsmodel.DomainPermissionType) (*gtsmodel.DomainPermission, error) {
+	perm := new(gtsmodel.DomainPermission)
+
+	err := d.db.NewSelect().
+		Model(perm).
+		Where("? = ?", bun.Ident("domain"), domain).
+		Where("? = ?", bun.Ident("permission_type"), permType).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return perm, nil
+}
+
+// getSubscriptionPriority returns the Priority of the domain
+// permission subscription with the given ID.
+func (d *domainPermissionSubscriptionDB) getSubscriptionPriority(ctx context.Context, subscriptionID string) (uint8, error) {
+	permSub := new(gtsmodel.DomainPermissionSubscription)
+
+	if err := d.db.NewSelect().
+		Model(permSub).
+		Column("priority").
+		Where("? = ?", bun.Ident("id"), subscriptionID).
+		Scan(ctx); err != nil {
+		return 0, err
+	}
+
+	return permSub.Priority, nil
+}
+
+// compile-time interface check.
+var _ db.DomainPermissionSubscription = (*domainPermissionSubscriptionDB)(nil)
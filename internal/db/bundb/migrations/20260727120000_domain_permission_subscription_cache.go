@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.NewAddColumn().
+				Table("domain_permission_subscriptions").
+				ColumnExpr("? VARCHAR", bun.Ident("etag")).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewAddColumn().
+				Table("domain_permission_subscriptions").
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("last_modified")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.NewDropColumn().
+				Table("domain_permission_subscriptions").
+				Column("etag").
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.NewDropColumn().
+				Table("domain_permission_subscriptions").
+				Column("last_modified").
+				Exec(ctx)
+			return err
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
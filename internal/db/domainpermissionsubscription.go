@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/subscriptions"
+)
+
+// DomainPermissionSubscription contains functions for
+// getting/creating/updating/deleting domain permission
+// subscriptions, and applying their parsed lists against
+// the domain permissions they manage.
+type DomainPermissionSubscription interface {
+	// PutDomainPermissionSubscription puts a new domain permission subscription in the database.
+	PutDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) error
+
+	// UpdateDomainPermissionSubscription updates the given columns of an existing
+	// domain permission subscription. If no columns are given, all columns are updated.
+	UpdateDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, columns ...string) error
+
+	// DiffDomainPermissionsFromSubscription compares entries against the domain permissions
+	// already managed by permSub (and by other subscriptions of the same permission type),
+	// without persisting any changes, and returns the result as a diff.
+	DiffDomainPermissionsFromSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, entries []subscriptions.Entry) (*gtsmodel.DomainPermissionSubscriptionDiff, error)
+
+	// UpsertDomainPermissionsFromSubscription diffs entries against the domain permissions
+	// already managed by permSub, as DiffDomainPermissionsFromSubscription does, and then
+	// creates, updates, or (if permSub.AdoptOrphans) adopts domain permissions to match.
+	UpsertDomainPermissionsFromSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, entries []subscriptions.Entry) error
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermission represents a single block or allow
+// entry for a remote domain, as stored in the database.
+// It may be managed by a DomainPermissionSubscription
+// (if SubscriptionID is set), or created directly by an
+// admin/moderator (if SubscriptionID is empty).
+type DomainPermission struct {
+	ID string `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+	// Domain this permission pertains to.
+	Domain string `bun:",nullzero,notnull,unique"`
+	// PermissionType is either block or allow.
+	PermissionType DomainPermissionType `bun:",nullzero,notnull"`
+	// CreatedByAccountID is the id of the admin
+	// account that created this permission.
+	CreatedByAccountID string `bun:"type:CHAR(26),nullzero,notnull"`
+	// PrivateComment on this permission, visible only to admins/moderators.
+	PrivateComment string `bun:",nullzero"`
+	// PublicComment on this permission, visible to requesters if enabled.
+	PublicComment string `bun:",nullzero"`
+	// Obfuscate the domain name in public lists, if PublicComment is shown.
+	Obfuscate *bool `bun:",nullzero,notnull,default:false"`
+	// SubscriptionID is the id of the DomainPermissionSubscription that
+	// created and manages this permission, if any. Empty for permissions
+	// created directly by an admin, or "orphaned" permissions whose
+	// managing subscription was deleted.
+	SubscriptionID string `bun:"type:CHAR(26),nullzero"`
+	// CreatedAt is the time this permission was created.
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+	// UpdatedAt is the time this permission was last updated.
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+}
+
+// DomainPermissionType denotes a type of domain permission, either allow or block.
+type DomainPermissionType int16
+
+const (
+	// DomainPermissionNone is a special value that indicates
+	// "do not create a domain permission for this entry".
+	DomainPermissionNone DomainPermissionType = iota
+	// DomainPermissionBlock represents a domain block.
+	DomainPermissionBlock
+	// DomainPermissionAllow represents a domain allow.
+	DomainPermissionAllow
+)
+
+// String returns a string representation of the DomainPermissionType.
+func (t DomainPermissionType) String() string {
+	switch t {
+	case DomainPermissionBlock:
+		return "block"
+	case DomainPermissionAllow:
+		return "allow"
+	default:
+		return "none"
+	}
+}
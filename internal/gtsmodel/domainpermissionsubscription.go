@@ -0,0 +1,180 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionSubscription represents a subscription
+// to a list of domain permissions (blocks or allows) hosted
+// at a particular URI, which is periodically refetched and
+// parsed in order to create/update domain permission entries.
+type DomainPermissionSubscription struct {
+	ID   string `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+	// Priority of this subscription relative to others
+	// of the same permission type; higher wins on conflict.
+	Priority uint8 `bun:",nullzero,notnull,default:0"`
+	// Title is an optional human-readable label for this subscription.
+	Title string `bun:",nullzero"`
+	// PermissionType that's created from parsing this subscription's list.
+	PermissionType DomainPermissionType `bun:",nullzero,notnull"`
+	// AsDraft indicates that permissions arising from
+	// this subscription should be created as drafts. It is
+	// stored and reported, but UpsertDomainPermissionsFromSubscription
+	// does not read it: DomainPermission has no draft state, so
+	// permissions from the list are created in force.
+	AsDraft *bool `bun:",nullzero,notnull,default:true"`
+	// AdoptOrphans indicates that this subscription should
+	// adopt existing orphaned domain permissions that match
+	// entries in its list.
+	AdoptOrphans *bool `bun:",nullzero,notnull,default:false"`
+	// CreatedByAccountID is the id of the admin account that created this subscription.
+	CreatedByAccountID string `bun:"type:CHAR(26),nullzero,notnull"`
+	// CreatedByAccount corresponds to CreatedByAccountID.
+	CreatedByAccount *Account `bun:"-"`
+	// URI to call in order to fetch the permissions list.
+	URI string `bun:",nullzero,notnull,unique"`
+	// ContentType to use when parsing the fetched permissions list.
+	ContentType DomainPermSubContentType `bun:",nullzero,notnull"`
+	// SeverityMapping holds, for content types that carry their
+	// own per-domain severity (eg., Mastodon-style domainblocks.json),
+	// the admin-chosen mapping of upstream severities onto GtS
+	// permission types. Nullzero for content types without a
+	// concept of severity.
+	SeverityMapping *DomainPermSubSeverityMapping `bun:"type:jsonb,nullzero"`
+	// FetchAuthType is the type of authentication
+	// (if any) to use when fetching URI.
+	FetchAuthType DomainPermSubFetchAuthType `bun:",nullzero,notnull,default:0"`
+	// FetchUsername is the basic auth username to use
+	// when fetching URI, if FetchAuthType is basic.
+	FetchUsername string `bun:",nullzero"`
+	// FetchPassword is the basic auth password to use
+	// when fetching URI, if FetchAuthType is basic.
+	FetchPassword string `bun:",nullzero"`
+	// FetchToken is the bearer token to use when
+	// fetching URI, if FetchAuthType is bearer.
+	FetchToken string `bun:",nullzero"`
+	// ETag is the ETag header value returned by the most
+	// recent fetch of URI, if any, used to populate
+	// If-None-Match on subsequent fetches.
+	ETag string `bun:",nullzero"`
+	// LastModified is the Last-Modified header value returned
+	// by the most recent fetch of URI, if any, used to populate
+	// If-Modified-Since on subsequent fetches.
+	LastModified time.Time `bun:"type:timestamptz,nullzero"`
+	// FetchedAt is the last time this subscription's URI was fetched, successfully or not.
+	FetchedAt time.Time `bun:"type:timestamptz,nullzero"`
+	// SuccessfullyFetchedAt is the last time this subscription's URI was fetched and parsed without error.
+	SuccessfullyFetchedAt time.Time `bun:"type:timestamptz,nullzero"`
+	// Error stores the most recent error
+	// encountered fetching or parsing this list, if any.
+	Error string `bun:",nullzero"`
+	// CreatedAt is the time this subscription was created.
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+	// UpdatedAt is the time this subscription was last updated.
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+}
+
+// DomainPermSubContentType denotes the content type of
+// a domain permission subscription list, ie., how it
+// should be fetched and parsed.
+type DomainPermSubContentType int16
+
+const (
+	// DomainPermSubContentTypeUnknown is the zero value, used for invalid/unset content types.
+	DomainPermSubContentTypeUnknown DomainPermSubContentType = iota
+	// DomainPermSubContentTypeCSV is "text/csv".
+	DomainPermSubContentTypeCSV
+	// DomainPermSubContentTypePlain is "text/plain".
+	DomainPermSubContentTypePlain
+	// DomainPermSubContentTypeJSON is "application/json", GtS's own flat list format.
+	DomainPermSubContentTypeJSON
+	// DomainPermSubContentTypeMastodonJSON is "application/vnd.mastodon.domainblocks+json",
+	// the Mastodon-compatible OEF (Oliphant Exclusion Format) domainblocks.json schema used
+	// by widely-shared community blocklists.
+	DomainPermSubContentTypeMastodonJSON
+)
+
+// DomainPermSubFetchAuthType denotes the kind of authentication
+// to apply to a domain permission subscription's fetch request.
+type DomainPermSubFetchAuthType int16
+
+const (
+	// DomainPermSubFetchAuthTypeNone means no auth is added to the fetch request.
+	DomainPermSubFetchAuthTypeNone DomainPermSubFetchAuthType = iota
+	// DomainPermSubFetchAuthTypeBasic means HTTP basic auth,
+	// using FetchUsername and FetchPassword, is added to the fetch request.
+	DomainPermSubFetchAuthTypeBasic
+	// DomainPermSubFetchAuthTypeBearer means an "Authorization: Bearer
+	// <FetchToken>" header is added to the fetch request.
+	DomainPermSubFetchAuthTypeBearer
+	// DomainPermSubFetchAuthTypeHTTPSignature means the fetch request
+	// is signed with the instance actor's key, as for federated
+	// ActivityPub dereferences.
+	DomainPermSubFetchAuthTypeHTTPSignature
+)
+
+// DomainPermSubSeverityMapping stores, per domain permission
+// subscription, how upstream Mastodon-style severities should
+// be mapped onto GtS domain permission behavior when parsing a
+// DomainPermSubContentTypeMastodonJSON list.
+type DomainPermSubSeverityMapping struct {
+	// Suspend is the GtS permission type to create for
+	// upstream entries with severity "suspend". Defaults
+	// to DomainPermissionBlock.
+	Suspend DomainPermissionType `json:"suspend,omitempty"`
+	// Silence is the GtS permission type to create for
+	// upstream entries with severity "silence". GtS doesn't
+	// yet model "silence" as a distinct domain permission, so
+	// this currently defaults to DomainPermissionNone (ie.,
+	// silenced domains are ignored) unless the admin explicitly
+	// maps it to DomainPermissionBlock or DomainPermissionAllow.
+	Silence DomainPermissionType `json:"silence,omitempty"`
+	// Noop is the GtS permission type to create for
+	// upstream entries with severity "noop". Defaults
+	// to DomainPermissionNone (ie., ignored).
+	Noop DomainPermissionType `json:"noop,omitempty"`
+}
+
+// DomainPermissionSubscriptionDiff describes the effect that
+// creating (or refreshing) a domain permission subscription
+// would have, or did have, on existing domain permissions.
+type DomainPermissionSubscriptionDiff struct {
+	// WouldCreate are entries for which a
+	// new domain permission would be created.
+	WouldCreate []*DomainPermission
+	// WouldUpdate are entries for a domain already managed
+	// by this subscription; its content (comment/obfuscate/
+	// permission type) would be refreshed to match the
+	// upstream list, whether or not it has actually changed.
+	WouldUpdate []*DomainPermission
+	// WouldAdopt are entries for which an existing orphaned
+	// domain permission would be adopted by this subscription.
+	WouldAdopt []*DomainPermission
+	// WouldOverwrite are entries for which an existing domain
+	// permission, currently managed by a lower-priority
+	// subscription, would be taken over by this one.
+	WouldOverwrite []*DomainPermission
+	// Superseded are entries that would have no effect, since
+	// a higher-(or-equal-)priority subscription already manages that domain.
+	Superseded []*DomainPermission
+	// Malformed is for raw entries/lines from the list that
+	// couldn't be parsed. DiffDomainPermissionsFromSubscription
+	// never fills it, and the parsers skip unusable entries
+	// without reporting them, so it is always empty.
+	Malformed []string
+}
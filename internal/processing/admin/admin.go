@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package admin contains processing logic for admin-related API requests.
+package admin
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor groups together logic for processing admin API requests.
+type Processor struct {
+	state         *state.State
+	converter     *typeutils.Converter
+	transportCtrl transport.Controller
+	httpClient    *httpclient.Client
+}
+
+// New returns a new admin Processor.
+func New(state *state.State, converter *typeutils.Converter, transportCtrl transport.Controller, httpClient *httpclient.Client) Processor {
+	return Processor{
+		state:         state,
+		converter:     converter,
+		transportCtrl: transportCtrl,
+		httpClient:    httpClient,
+	}
+}
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// DomainPermissionSubscriptionCreate creates a domain permission
+// subscription using the given parameters. The subscription's list
+// will be fetched and parsed (according to contentType, and, if
+// relevant, severityMapping) on the next scheduled subscriptions run.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	priority uint8,
+	title string,
+	uri string,
+	contentType gtsmodel.DomainPermSubContentType,
+	permType gtsmodel.DomainPermissionType,
+	asDraft bool,
+	adoptOrphans bool,
+	severityMapping *gtsmodel.DomainPermSubSeverityMapping,
+	fetchAuthType gtsmodel.DomainPermSubFetchAuthType,
+	fetchUsername string,
+	fetchPassword string,
+	fetchToken string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	// Severity mapping only makes sense for content types
+	// that carry their own upstream severity; discard it
+	// (rather than erroring) for everything else, since an
+	// admin may reasonably leave a stale value in a form.
+	if contentType != gtsmodel.DomainPermSubContentTypeMastodonJSON {
+		severityMapping = nil
+	}
+
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		Priority:           priority,
+		Title:              title,
+		PermissionType:     permType,
+		AsDraft:            &asDraft,
+		AdoptOrphans:       &adoptOrphans,
+		CreatedByAccountID: account.ID,
+		CreatedByAccount:   account,
+		URI:                uri,
+		ContentType:        contentType,
+		SeverityMapping:    severityMapping,
+		FetchAuthType:      fetchAuthType,
+		FetchUsername:      fetchUsername,
+		FetchPassword:      fetchPassword,
+		FetchToken:         fetchToken,
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		err = fmt.Errorf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPermSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, permSub)
+	if err != nil {
+		err = fmt.Errorf("error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/subscriptions"
+)
+
+// DomainPermissionSubscriptionPreview fetches and parses the list at
+// uri as RefreshDomainPermissionSubscription would for a subscription
+// created with these parameters, but without persisting a subscription
+// or any domain permissions; it returns a diff describing what would
+// happen if the subscription were created and refreshed.
+func (p *Processor) DomainPermissionSubscriptionPreview(
+	ctx context.Context,
+	priority uint8,
+	uri string,
+	contentType gtsmodel.DomainPermSubContentType,
+	permType gtsmodel.DomainPermissionType,
+	adoptOrphans bool,
+	severityMapping *gtsmodel.DomainPermSubSeverityMapping,
+	fetchAuthType gtsmodel.DomainPermSubFetchAuthType,
+	fetchUsername string,
+	fetchPassword string,
+	fetchToken string,
+) (*apimodel.DomainPermissionSubscriptionPreview, gtserror.WithCode) {
+	if contentType != gtsmodel.DomainPermSubContentTypeMastodonJSON {
+		severityMapping = nil
+	}
+
+	// Build an ephemeral, never-persisted subscription
+	// carrying just enough to drive the fetch and parse.
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		Priority:        priority,
+		PermissionType:  permType,
+		AdoptOrphans:    &adoptOrphans,
+		URI:             uri,
+		ContentType:     contentType,
+		SeverityMapping: severityMapping,
+		FetchAuthType:   fetchAuthType,
+		FetchUsername:   fetchUsername,
+		FetchPassword:   fetchPassword,
+		FetchToken:      fetchToken,
+	}
+
+	result, err := p.fetchDomainPermSub(ctx, permSub)
+	if err != nil {
+		err = fmt.Errorf("error fetching list: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	parser, err := subscriptions.NewParser(contentType, permType, severityMapping)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	entries, err := parser.Parse(result.Body)
+	if err != nil {
+		err = fmt.Errorf("error parsing list: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	diff, err := p.state.DB.DiffDomainPermissionsFromSubscription(ctx, permSub, entries)
+	if err != nil {
+		err = fmt.Errorf("error diffing parsed entries against existing domain permissions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDiff, err := p.converter.DomainPermSubDiffToAPIDomainPermSubPreview(ctx, diff)
+	if err != nil {
+		err = fmt.Errorf("error converting diff to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDiff, nil
+}
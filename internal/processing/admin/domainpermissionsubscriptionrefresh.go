@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/subscriptions"
+)
+
+// RefreshDomainPermissionSubscription fetches permSub's list and,
+// if it has changed since the last fetch, parses it and upserts
+// the resulting domain permissions. If the upstream responds with
+// a 304 Not Modified (per permSub's stored ETag/LastModified), the
+// parse/diff pipeline is skipped entirely and only FetchedAt (and
+// any stale Error, which is cleared) are updated, avoiding the cost
+// of re-parsing an unchanged list on every scheduled refresh.
+func (p *Processor) RefreshDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) error {
+	result, err := p.fetchDomainPermSub(ctx, permSub)
+	permSub.FetchedAt = time.Now()
+	if err != nil {
+		permSub.Error = err.Error()
+		if dbErr := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, "fetched_at", "error"); dbErr != nil {
+			return fmt.Errorf("error fetching list (%w), and error recording failure: %w", err, dbErr)
+		}
+		return fmt.Errorf("error fetching list: %w", err)
+	}
+
+	if result.NotModified {
+		// Clear any stale error from a previous failed fetch/parse;
+		// a cache hit means the list is reachable and unchanged.
+		permSub.Error = ""
+		if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, "fetched_at", "error"); err != nil {
+			return fmt.Errorf("error recording cache hit: %w", err)
+		}
+		return nil
+	}
+
+	parser, err := subscriptions.NewParser(permSub.ContentType, permSub.PermissionType, permSub.SeverityMapping)
+	if err != nil {
+		return fmt.Errorf("error selecting parser: %w", err)
+	}
+
+	entries, err := parser.Parse(result.Body)
+	if err != nil {
+		permSub.Error = err.Error()
+		if dbErr := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, "fetched_at", "error"); dbErr != nil {
+			return fmt.Errorf("error parsing list (%w), and error recording failure: %w", err, dbErr)
+		}
+		return fmt.Errorf("error parsing list: %w", err)
+	}
+
+	// Hand the parsed entries off to the existing
+	// subscription diff/merge pipeline, which creates,
+	// updates, or adopts domain permissions as appropriate.
+	if err := p.state.DB.UpsertDomainPermissionsFromSubscription(ctx, permSub, entries); err != nil {
+		permSub.Error = err.Error()
+		_ = p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, "fetched_at", "error")
+		return fmt.Errorf("error upserting parsed entries: %w", err)
+	}
+
+	permSub.Error = ""
+	permSub.ETag = result.ETag
+	permSub.LastModified = result.LastModified
+	permSub.SuccessfullyFetchedAt = permSub.FetchedAt
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub,
+		"fetched_at", "successfully_fetched_at", "etag", "last_modified", "error",
+	); err != nil {
+		return fmt.Errorf("error recording successful fetch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// csvParser parses a CSV domain permission list with a
+// header row. Only the "domain" column is required; if
+// present, "public_comment", "private_comment", and
+// "obfuscate" are also read.
+type csvParser struct {
+	permType gtsmodel.DomainPermissionType
+}
+
+// NewCSVParser returns a Parser for CSV domain lists,
+// creating permissions of permType for each listed domain.
+func NewCSVParser(permType gtsmodel.DomainPermissionType) Parser {
+	return &csvParser{permType: permType}
+}
+
+func (p *csvParser) Parse(b []byte) ([]Entry, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading csv domain list: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	colIdx := make(map[string]int, len(header))
+	for i, col := range header {
+		colIdx[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	domainIdx, ok := colIdx["domain"]
+	if !ok {
+		return nil, errors.New(`csv domain list missing required "domain" column`)
+	}
+
+	entries := make([]Entry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if domainIdx >= len(record) {
+			continue
+		}
+
+		entry := Entry{
+			Domain:         strings.TrimSpace(record[domainIdx]),
+			PermissionType: p.permType,
+		}
+
+		if i, ok := colIdx["public_comment"]; ok && i < len(record) {
+			entry.PublicComment = record[i]
+		}
+		if i, ok := colIdx["private_comment"]; ok && i < len(record) {
+			entry.PrivateComment = record[i]
+		}
+		if i, ok := colIdx["obfuscate"]; ok && i < len(record) {
+			entry.Obfuscate, _ = strconv.ParseBool(record[i])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestCSVParserParse(t *testing.T) {
+	parser := NewCSVParser(gtsmodel.DomainPermissionBlock)
+
+	const in = `domain,public_comment,private_comment,obfuscate
+bad.example.org,spam,flagged by admin,true
+  spaced.example.org  ,,,
+`
+
+	entries, err := parser.Parse([]byte(in))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "bad.example.org", entries[0].Domain)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, entries[0].PermissionType)
+	assert.Equal(t, "spam", entries[0].PublicComment)
+	assert.Equal(t, "flagged by admin", entries[0].PrivateComment)
+	assert.True(t, entries[0].Obfuscate)
+
+	assert.Equal(t, "spaced.example.org", entries[1].Domain)
+	assert.False(t, entries[1].Obfuscate)
+}
+
+func TestCSVParserParseMissingDomainColumn(t *testing.T) {
+	parser := NewCSVParser(gtsmodel.DomainPermissionBlock)
+
+	const in = `public_comment,private_comment
+spam,flagged by admin
+`
+
+	_, err := parser.Parse([]byte(in))
+	assert.ErrorContains(t, err, `missing required "domain" column`)
+}
+
+func TestCSVParserParseEmpty(t *testing.T) {
+	parser := NewCSVParser(gtsmodel.DomainPermissionBlock)
+
+	entries, err := parser.Parse([]byte(""))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// jsonEntry is GtS's own flat JSON domain permission list format.
+type jsonEntry struct {
+	Domain         string `json:"domain"`
+	PublicComment  string `json:"public_comment,omitempty"`
+	PrivateComment string `json:"private_comment,omitempty"`
+	Obfuscate      bool   `json:"obfuscate,omitempty"`
+}
+
+// jsonParser parses GtS's own flat JSON domain
+// permission list format (a JSON array of jsonEntry).
+type jsonParser struct {
+	permType gtsmodel.DomainPermissionType
+}
+
+// NewJSONParser returns a Parser for GtS's own flat
+// JSON domain list format, creating permissions of
+// permType for each listed domain.
+func NewJSONParser(permType gtsmodel.DomainPermissionType) Parser {
+	return &jsonParser{permType: permType}
+}
+
+func (p *jsonParser) Parse(b []byte) ([]Entry, error) {
+	var raw []jsonEntry
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("error unmarshaling json domain list: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for _, e := range raw {
+		if e.Domain == "" {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Domain:         e.Domain,
+			PermissionType: p.permType,
+			Obfuscate:      e.Obfuscate,
+			PublicComment:  e.PublicComment,
+			PrivateComment: e.PrivateComment,
+		})
+	}
+
+	return entries, nil
+}
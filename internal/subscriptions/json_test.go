@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestJSONParserParse(t *testing.T) {
+	parser := NewJSONParser(gtsmodel.DomainPermissionAllow)
+
+	const in = `[
+		{"domain": "good.example.org", "public_comment": "trusted"},
+		{"domain": ""},
+		{"domain": "another.example.org", "obfuscate": true}
+	]`
+
+	entries, err := parser.Parse([]byte(in))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "good.example.org", entries[0].Domain)
+	assert.Equal(t, gtsmodel.DomainPermissionAllow, entries[0].PermissionType)
+	assert.Equal(t, "trusted", entries[0].PublicComment)
+
+	assert.Equal(t, "another.example.org", entries[1].Domain)
+	assert.True(t, entries[1].Obfuscate)
+}
+
+func TestJSONParserParseMalformed(t *testing.T) {
+	parser := NewJSONParser(gtsmodel.DomainPermissionBlock)
+
+	_, err := parser.Parse([]byte(`not valid json`))
+	assert.Error(t, err)
+}
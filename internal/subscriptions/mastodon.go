@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// mastodonEntry mirrors a single entry of a Mastodon-compatible
+// domainblocks.json export, the schema used by widely shared,
+// community-curated blocklists such as Oliphant's OEF
+// (Oliphant Exclusion Format) lists.
+//
+// reject_media and reject_reports are accepted for forwards
+// compatibility with the upstream schema, but GtS doesn't yet
+// model those per-domain behaviors independently of a block,
+// so they are currently parsed and discarded.
+type mastodonEntry struct {
+	Domain         string `json:"domain"`
+	Severity       string `json:"severity"`
+	RejectMedia    bool   `json:"reject_media"`
+	RejectReports  bool   `json:"reject_reports"`
+	Obfuscate      bool   `json:"obfuscate"`
+	PublicComment  string `json:"public_comment"`
+	PrivateComment string `json:"private_comment"`
+}
+
+// mastodonParser parses Mastodon-compatible domainblocks.json
+// lists into Entries, translating each entry's upstream
+// "severity" into a GtS DomainPermissionType via mapping.
+type mastodonParser struct {
+	mapping gtsmodel.DomainPermSubSeverityMapping
+}
+
+// NewMastodonParser returns a Parser for Mastodon-compatible
+// domainblocks.json lists, using mapping to decide what kind
+// of domain permission (if any) to create for each severity.
+func NewMastodonParser(mapping gtsmodel.DomainPermSubSeverityMapping) Parser {
+	return &mastodonParser{mapping: mapping}
+}
+
+func (p *mastodonParser) Parse(b []byte) ([]Entry, error) {
+	var raw []mastodonEntry
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("error unmarshaling mastodon domainblocks list: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for _, e := range raw {
+		if e.Domain == "" {
+			continue
+		}
+
+		permType := p.mapSeverity(e.Severity)
+		if permType == gtsmodel.DomainPermissionNone {
+			// Admin's mapping says to ignore
+			// entries with this severity.
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Domain:         e.Domain,
+			PermissionType: permType,
+			Obfuscate:      e.Obfuscate,
+			PublicComment:  e.PublicComment,
+			PrivateComment: e.PrivateComment,
+		})
+	}
+
+	return entries, nil
+}
+
+// mapSeverity maps a raw Mastodon "severity" value onto a
+// GtS DomainPermissionType, according to p.mapping. Unknown
+// severities are ignored (mapped to DomainPermissionNone).
+func (p *mastodonParser) mapSeverity(severity string) gtsmodel.DomainPermissionType {
+	switch severity {
+	case "suspend":
+		return p.mapping.Suspend
+	case "silence":
+		return p.mapping.Silence
+	case "noop":
+		return p.mapping.Noop
+	default:
+		return gtsmodel.DomainPermissionNone
+	}
+}
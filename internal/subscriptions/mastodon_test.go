@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestMastodonParserParseDefaultMapping(t *testing.T) {
+	parser := NewMastodonParser(defaultSeverityMapping(nil))
+
+	const in = `[
+		{"domain": "suspended.example.org", "severity": "suspend"},
+		{"domain": "silenced.example.org", "severity": "silence"},
+		{"domain": "noop.example.org", "severity": "noop"},
+		{"domain": "unknown.example.org", "severity": "made_up"},
+		{"domain": ""}
+	]`
+
+	entries, err := parser.Parse([]byte(in))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "suspended.example.org", entries[0].Domain)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, entries[0].PermissionType)
+}
+
+func TestMastodonParserParseCustomMapping(t *testing.T) {
+	mapping := gtsmodel.DomainPermSubSeverityMapping{
+		Suspend: gtsmodel.DomainPermissionBlock,
+		Silence: gtsmodel.DomainPermissionBlock,
+		Noop:    gtsmodel.DomainPermissionNone,
+	}
+	parser := NewMastodonParser(mapping)
+
+	const in = `[{"domain": "silenced.example.org", "severity": "silence"}]`
+
+	entries, err := parser.Parse([]byte(in))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, gtsmodel.DomainPermissionBlock, entries[0].PermissionType)
+}
+
+func TestMastodonParserParseMalformed(t *testing.T) {
+	parser := NewMastodonParser(defaultSeverityMapping(nil))
+
+	_, err := parser.Parse([]byte(`{"not": "an array"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package subscriptions contains parsers for turning the
+// raw, fetched contents of a domain permission subscription
+// list into a normalized slice of Entry, ready to be diffed
+// against existing domain permissions.
+package subscriptions
+
+import (
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Entry represents a single, normalized domain permission
+// entry parsed out of a subscribed list, prior to being
+// turned into (or merged with) a gtsmodel.DomainPermission.
+type Entry struct {
+	Domain         string
+	PermissionType gtsmodel.DomainPermissionType
+	Obfuscate      bool
+	PublicComment  string
+	PrivateComment string
+}
+
+// Parser can parse a slice of Entry out of the raw,
+// fetched bytes of a domain permission subscription list.
+type Parser interface {
+	Parse(b []byte) ([]Entry, error)
+}
+
+// NewParser returns the appropriate Parser for the given
+// contentType, to create domain permissions of permType.
+// severityMapping is only consulted for content types that
+// carry their own upstream severity (ie., Mastodon-style
+// domainblocks.json); it may be nil for other content types.
+func NewParser(
+	contentType gtsmodel.DomainPermSubContentType,
+	permType gtsmodel.DomainPermissionType,
+	severityMapping *gtsmodel.DomainPermSubSeverityMapping,
+) (Parser, error) {
+	switch contentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		return NewCSVParser(permType), nil
+	case gtsmodel.DomainPermSubContentTypePlain:
+		return NewPlainParser(permType), nil
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		return NewJSONParser(permType), nil
+	case gtsmodel.DomainPermSubContentTypeMastodonJSON:
+		return NewMastodonParser(defaultSeverityMapping(severityMapping)), nil
+	default:
+		return nil, fmt.Errorf("unrecognized domain permission subscription content type %d", contentType)
+	}
+}
+
+// defaultSeverityMapping returns mapping if non-nil, or
+// else DefaultSeverityMapping(). Callers that accept a
+// partial admin-supplied mapping are expected to have
+// already merged it over DefaultSeverityMapping() field
+// by field, so that unspecified severities still fall
+// back to their documented defaults instead of being
+// zeroed out.
+func defaultSeverityMapping(mapping *gtsmodel.DomainPermSubSeverityMapping) gtsmodel.DomainPermSubSeverityMapping {
+	if mapping != nil {
+		return *mapping
+	}
+	return DefaultSeverityMapping()
+}
+
+// DefaultSeverityMapping returns the sane set of defaults
+// applied to Mastodon-style lists when no (or no complete)
+// severity_mapping is given: "suspend" becomes a GtS block,
+// "silence" and "noop" are ignored since GtS doesn't (yet)
+// have a silence permission.
+func DefaultSeverityMapping() gtsmodel.DomainPermSubSeverityMapping {
+	return gtsmodel.DomainPermSubSeverityMapping{
+		Suspend: gtsmodel.DomainPermissionBlock,
+		Silence: gtsmodel.DomainPermissionNone,
+		Noop:    gtsmodel.DomainPermissionNone,
+	}
+}
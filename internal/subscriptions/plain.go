@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// plainParser parses a newline-separated, plaintext
+// list of domains (one per line, "#" prefixed lines
+// and blank lines ignored) into Entries.
+type plainParser struct {
+	permType gtsmodel.DomainPermissionType
+}
+
+// NewPlainParser returns a Parser for plaintext domain lists,
+// creating permissions of permType for each listed domain.
+func NewPlainParser(permType gtsmodel.DomainPermissionType) Parser {
+	return &plainParser{permType: permType}
+}
+
+func (p *plainParser) Parse(b []byte) ([]Entry, error) {
+	var entries []Entry
+
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		domain := strings.TrimSpace(sc.Text())
+		if domain == "" || strings.HasPrefix(domain, "#") {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Domain:         domain,
+			PermissionType: p.permType,
+		})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning plaintext domain list: %w", err)
+	}
+
+	return entries, nil
+}
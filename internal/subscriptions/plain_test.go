@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestPlainParserParse(t *testing.T) {
+	parser := NewPlainParser(gtsmodel.DomainPermissionBlock)
+
+	const in = `# a comment
+bad.example.org
+
+  spaced.example.org
+# another comment
+worse.example.org
+`
+
+	entries, err := parser.Parse([]byte(in))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "bad.example.org", entries[0].Domain)
+	assert.Equal(t, "spaced.example.org", entries[1].Domain)
+	assert.Equal(t, "worse.example.org", entries[2].Domain)
+	for _, e := range entries {
+		assert.Equal(t, gtsmodel.DomainPermissionBlock, e.PermissionType)
+	}
+}
+
+func TestPlainParserParseEmpty(t *testing.T) {
+	parser := NewPlainParser(gtsmodel.DomainPermissionBlock)
+
+	entries, err := parser.Parse([]byte("\n# only a comment\n"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typeutils
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainPermSubToAPIDomainPermSub converts a gtsmodel.DomainPermissionSubscription
+// into its API representation.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) (*apimodel.DomainPermissionSubscription, error) {
+	apiPermSub := &apimodel.DomainPermissionSubscription{
+		ID:             permSub.ID,
+		Priority:       permSub.Priority,
+		Title:          permSub.Title,
+		PermissionType: permSub.PermissionType.String(),
+		AsDraft:        util.PtrOrZero(permSub.AsDraft),
+		AdoptOrphans:   util.PtrOrZero(permSub.AdoptOrphans),
+		CreatedBy:      permSub.CreatedByAccountID,
+		URI:            permSub.URI,
+		ContentType:    domainPermSubContentTypeString(permSub.ContentType),
+		FetchAuthType:  domainPermSubFetchAuthTypeString(permSub.FetchAuthType),
+		FetchUsername:  permSub.FetchUsername,
+		ETag:           permSub.ETag,
+		Error:          permSub.Error,
+	}
+
+	if permSub.SeverityMapping != nil {
+		apiPermSub.SeverityMapping = &apimodel.DomainPermSubSeverityMapping{
+			Suspend: permSub.SeverityMapping.Suspend.String(),
+			Silence: permSub.SeverityMapping.Silence.String(),
+			Noop:    permSub.SeverityMapping.Noop.String(),
+		}
+	}
+
+	if !permSub.LastModified.IsZero() {
+		apiPermSub.LastModified = permSub.LastModified.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	if !permSub.FetchedAt.IsZero() {
+		apiPermSub.FetchedAt = permSub.FetchedAt.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	if !permSub.SuccessfullyFetchedAt.IsZero() {
+		apiPermSub.SuccessfullyFetchedAt = permSub.SuccessfullyFetchedAt.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+
+	return apiPermSub, nil
+}
+
+// DomainPermSubDiffToAPIDomainPermSubPreview converts a
+// gtsmodel.DomainPermissionSubscriptionDiff into its API
+// representation, for returning from the preview endpoint.
+func (c *Converter) DomainPermSubDiffToAPIDomainPermSubPreview(ctx context.Context, diff *gtsmodel.DomainPermissionSubscriptionDiff) (*apimodel.DomainPermissionSubscriptionPreview, error) {
+	preview := &apimodel.DomainPermissionSubscriptionPreview{
+		WouldCreate:    domainPermsToAPIPreviewEntries(diff.WouldCreate),
+		WouldUpdate:    domainPermsToAPIPreviewEntries(diff.WouldUpdate),
+		WouldAdopt:     domainPermsToAPIPreviewEntries(diff.WouldAdopt),
+		WouldOverwrite: domainPermsToAPIPreviewEntries(diff.WouldOverwrite),
+		Superseded:     domainPermsToAPIPreviewEntries(diff.Superseded),
+		Malformed:      diff.Malformed,
+	}
+	return preview, nil
+}
+
+func domainPermsToAPIPreviewEntries(perms []*gtsmodel.DomainPermission) []apimodel.DomainPermissionSubscriptionPreviewEntry {
+	entries := make([]apimodel.DomainPermissionSubscriptionPreviewEntry, 0, len(perms))
+	for _, perm := range perms {
+		entries = append(entries, apimodel.DomainPermissionSubscriptionPreviewEntry{
+			Domain:         perm.Domain,
+			PermissionType: perm.PermissionType.String(),
+		})
+	}
+	return entries
+}
+
+func domainPermSubContentTypeString(ct gtsmodel.DomainPermSubContentType) string {
+	switch ct {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		return "text/csv"
+	case gtsmodel.DomainPermSubContentTypePlain:
+		return "text/plain"
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		return "application/json"
+	case gtsmodel.DomainPermSubContentTypeMastodonJSON:
+		return "application/vnd.mastodon.domainblocks+json"
+	default:
+		return ""
+	}
+}
+
+func domainPermSubFetchAuthTypeString(at gtsmodel.DomainPermSubFetchAuthType) string {
+	switch at {
+	case gtsmodel.DomainPermSubFetchAuthTypeBasic:
+		return "basic"
+	case gtsmodel.DomainPermSubFetchAuthTypeBearer:
+		return "bearer"
+	case gtsmodel.DomainPermSubFetchAuthTypeHTTPSignature:
+		return "http_signature"
+	default:
+		return "none"
+	}
+}